@@ -0,0 +1,17 @@
+package buildkite
+
+import (
+	"net/http"
+
+	"github.com/shurcooL/graphql"
+)
+
+// Client holds the configured connections used by every resource in the provider
+type Client struct {
+	organization string
+	graphql      *graphql.Client
+	http         *http.Client
+
+	// archiveOnDelete, when true, makes DeletePipeline archive a pipeline instead of deleting it
+	archiveOnDelete bool
+}
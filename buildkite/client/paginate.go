@@ -0,0 +1,27 @@
+// Package client holds transport- and pagination-level concerns shared by every Buildkite API call,
+// independent of any single Terraform resource. Query/mutation types (PipelineNode, TeamPipelineNode, ...)
+// are still hand-written in the buildkite package rather than generated (e.g. via genqlient); that
+// migration is a separate piece of work and is not part of this package.
+package client
+
+// PageInfo mirrors a Buildkite GraphQL connection's pageInfo field
+type PageInfo struct {
+	HasNextPage bool
+	EndCursor   string
+}
+
+// Paginate repeatedly invokes fetch, which should query one page starting at cursor (empty for the first
+// page) and return that page's PageInfo, until the API reports no further pages remain.
+func Paginate(fetch func(cursor string) (PageInfo, error)) error {
+	cursor := ""
+	for {
+		info, err := fetch(cursor)
+		if err != nil {
+			return err
+		}
+		if !info.HasNextPage {
+			return nil
+		}
+		cursor = info.EndCursor
+	}
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryingTransport wraps an http.RoundTripper with exponential backoff on 429 and 5xx responses,
+// honoring Buildkite's Retry-After and RateLimit-Reset headers when present.
+type RetryingTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int
+}
+
+// NewRetryingTransport returns a RetryingTransport wrapping base, retrying up to maxRetries times
+func NewRetryingTransport(base http.RoundTripper, maxRetries int) *RetryingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryingTransport{Base: base, MaxRetries: maxRetries}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt <= t.MaxRetries; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			// req.Body was already consumed (and closed) by the previous attempt; rebuild it from
+			// GetBody, which http.NewRequest populates for *bytes.Buffer/*bytes.Reader/*strings.Reader bodies.
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, getBodyErr := req.GetBody()
+			if getBodyErr != nil {
+				return resp, getBodyErr
+			}
+			req.Body = body
+		}
+
+		resp, err = t.Base.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return resp, nil
+		}
+		if attempt == t.MaxRetries {
+			return resp, nil
+		}
+
+		wait := retryDelay(resp.Header, attempt)
+		resp.Body.Close()
+		time.Sleep(wait)
+	}
+
+	return resp, err
+}
+
+// retryDelay determines how long to wait before the next attempt, preferring the server's Retry-After
+// header, falling back to RateLimit-Reset, and finally jittered exponential backoff.
+func retryDelay(headers http.Header, attempt int) time.Duration {
+	if retryAfter := headers.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	if reset := headers.Get("RateLimit-Reset"); reset != "" {
+		if seconds, err := strconv.Atoi(reset); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	backoff := math.Pow(2, float64(attempt)) * float64(time.Second)
+	jitter := rand.Float64() * float64(time.Second)
+	return time.Duration(backoff + jitter)
+}
@@ -0,0 +1,64 @@
+package buildkite
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/graphql"
+	bkclient "github.com/vgrigoruk/terraform-provider-buildkite/buildkite/client"
+	"golang.org/x/oauth2"
+)
+
+// Provider returns the buildkite terraform provider
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"api_token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("BUILDKITE_API_TOKEN", nil),
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BUILDKITE_ORGANIZATION", nil),
+			},
+			"archive_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Archive pipelines instead of deleting them when the resource is destroyed",
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"buildkite_pipeline":            resourcePipeline(),
+			"buildkite_pipeline_schedule":   resourcePipelineSchedule(),
+			"buildkite_pipelines_from_yaml": resourcePipelinesFromYAML(),
+			"buildkite_pipeline_order":      resourcePipelineOrder(),
+		},
+		ConfigureContextFunc: providerConfigure,
+	}
+}
+
+// providerConfigure builds the Client shared by every resource from the provider-level schema
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	token := d.Get("api_token").(string)
+	organization := d.Get("organization").(string)
+
+	httpClient := &http.Client{
+		Transport: &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+			Base:   bkclient.NewRetryingTransport(http.DefaultTransport, 4),
+		},
+	}
+
+	return &Client{
+		organization:    organization,
+		graphql:         graphql.NewClient("https://graphql.buildkite.com/v1", httpClient),
+		http:            httpClient,
+		archiveOnDelete: d.Get("archive_on_delete").(bool),
+	}, nil
+}
@@ -11,10 +11,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/graphql"
+	bkclient "github.com/vgrigoruk/terraform-provider-buildkite/buildkite/client"
 )
 
 // PipelineNode represents a pipeline as returned from the GraphQL API
 type PipelineNode struct {
+	Archived                             graphql.Boolean
 	CancelIntermediateBuilds             graphql.Boolean
 	CancelIntermediateBuildsBranchFilter graphql.String
 	DefaultBranch                        graphql.String
@@ -30,11 +32,6 @@ type PipelineNode struct {
 	Steps                              struct {
 		YAML graphql.String
 	}
-	Teams struct {
-		Edges []struct {
-			Node TeamPipelineNode
-		}
-	} `graphql:"teams(first: 50)"`
 	WebhookURL graphql.String `graphql:"webhookURL"`
 }
 
@@ -60,6 +57,11 @@ func resourcePipeline() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"archived": {
+				Optional: true,
+				Default:  false,
+				Type:     schema.TypeBool,
+			},
 			"cancel_intermediate_builds": {
 				Optional: true,
 				Default:  false,
@@ -141,6 +143,72 @@ func resourcePipeline() *schema.Resource {
 				Computed: true,
 				Type:     schema.TypeString,
 			},
+			"provider_settings": {
+				Optional: true,
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"trigger_mode": {
+							Optional: true,
+							Type:     schema.TypeString,
+						},
+						"build_pull_requests": {
+							Optional: true,
+							Default:  true,
+							Type:     schema.TypeBool,
+						},
+						"build_pull_request_forks": {
+							Optional: true,
+							Default:  false,
+							Type:     schema.TypeBool,
+						},
+						"pull_request_branch_filter_enabled": {
+							Optional: true,
+							Default:  false,
+							Type:     schema.TypeBool,
+						},
+						"pull_request_branch_filter_configuration": {
+							Optional: true,
+							Type:     schema.TypeString,
+						},
+						"skip_pull_request_builds_for_existing_commits": {
+							Optional: true,
+							Default:  true,
+							Type:     schema.TypeBool,
+						},
+						"build_tags": {
+							Optional: true,
+							Default:  false,
+							Type:     schema.TypeBool,
+						},
+						"publish_commit_status": {
+							Optional: true,
+							Default:  true,
+							Type:     schema.TypeBool,
+						},
+						"publish_blocked_as_pending": {
+							Optional: true,
+							Default:  false,
+							Type:     schema.TypeBool,
+						},
+						"separate_pull_request_statuses": {
+							Optional: true,
+							Default:  false,
+							Type:     schema.TypeBool,
+						},
+						"filter_enabled": {
+							Optional: true,
+							Default:  false,
+							Type:     schema.TypeBool,
+						},
+						"filter_condition": {
+							Optional: true,
+							Type:     schema.TypeString,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -172,7 +240,7 @@ func CreatePipeline(ctx context.Context, d *schema.ResourceData, m interface{})
 	}
 
 	log.Printf("Creating pipeline %s ...", vars["name"])
-	err = client.graphql.Mutate(context.Background(), &mutation, vars)
+	err = client.graphql.Mutate(ctx, &mutation, vars)
 	if err != nil {
 		log.Printf("Unable to create pipeline %s", d.Get("name"))
 		return diag.FromErr(err)
@@ -180,14 +248,24 @@ func CreatePipeline(ctx context.Context, d *schema.ResourceData, m interface{})
 	log.Printf("Successfully created pipeline with id '%s'.", mutation.PipelineCreate.Pipeline.ID)
 
 	teamPipelines := getTeamPipelinesFromSchema(d)
-	err = reconcileTeamPipelines(teamPipelines, &mutation.PipelineCreate.Pipeline, client)
+	err = reconcileTeamPipelines(ctx, teamPipelines, &mutation.PipelineCreate.Pipeline, client)
 	if err != nil {
 		log.Print("Unable to create team pipelines")
 		return diag.FromErr(err)
 	}
 
-	updatePipelineResource(d, &mutation.PipelineCreate.Pipeline)
-	updatePipelineWithRESTfulAPI(d, client)
+	err = reconcileArchivedState(ctx, d, &mutation.PipelineCreate.Pipeline, client)
+	if err != nil {
+		log.Print("Unable to reconcile archived state")
+		return diag.FromErr(err)
+	}
+
+	if err := updatePipelineResource(ctx, d, &mutation.PipelineCreate.Pipeline, client); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updatePipelineWithRESTfulAPI(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ReadPipeline(ctx, d, m)
 }
@@ -204,12 +282,18 @@ func ReadPipeline(ctx context.Context, d *schema.ResourceData, m interface{}) di
 		"id": graphql.ID(d.Id()),
 	}
 
-	err := client.graphql.Query(context.Background(), &query, vars)
+	err := client.graphql.Query(ctx, &query, vars)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	updatePipelineResource(d, &query.Node.Pipeline)
+	if err := updatePipelineResource(ctx, d, &query.Node.Pipeline, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := readPipelineWithRESTfulAPI(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
@@ -236,21 +320,31 @@ func UpdatePipeline(ctx context.Context, d *schema.ResourceData, m interface{})
 	}
 
 	log.Printf("Updating pipeline %s ...", vars["name"])
-	err := client.graphql.Mutate(context.Background(), &mutation, vars)
+	err := client.graphql.Mutate(ctx, &mutation, vars)
 	if err != nil {
 		log.Printf("Unable to update pipeline %s", d.Get("name"))
 		return diag.FromErr(err)
 	}
 
 	teamPipelines := getTeamPipelinesFromSchema(d)
-	err = reconcileTeamPipelines(teamPipelines, &mutation.PipelineUpdate.Pipeline, client)
+	err = reconcileTeamPipelines(ctx, teamPipelines, &mutation.PipelineUpdate.Pipeline, client)
 	if err != nil {
 		log.Print("Unable to reconcile team pipelines")
 		return diag.FromErr(err)
 	}
 
-	updatePipelineResource(d, &mutation.PipelineUpdate.Pipeline)
-	updatePipelineWithRESTfulAPI(d, client)
+	err = reconcileArchivedState(ctx, d, &mutation.PipelineUpdate.Pipeline, client)
+	if err != nil {
+		log.Print("Unable to reconcile archived state")
+		return diag.FromErr(err)
+	}
+
+	if err := updatePipelineResource(ctx, d, &mutation.PipelineUpdate.Pipeline, client); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updatePipelineWithRESTfulAPI(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return ReadPipeline(ctx, d, m)
 }
@@ -259,6 +353,15 @@ func UpdatePipeline(ctx context.Context, d *schema.ResourceData, m interface{})
 func DeletePipeline(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
+	if client.archiveOnDelete {
+		log.Printf("Archiving pipeline %s instead of deleting (archive_on_delete is enabled) ...", d.Get("name"))
+		if err := archivePipeline(ctx, d.Id(), client); err != nil {
+			log.Printf("Unable to archive pipeline %s", d.Get("name"))
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
 	var mutation struct {
 		PipelineDelete struct {
 			Organization struct {
@@ -271,7 +374,7 @@ func DeletePipeline(ctx context.Context, d *schema.ResourceData, m interface{})
 	}
 
 	log.Printf("Deleting pipeline %s ...", d.Get("name"))
-	err := client.graphql.Mutate(context.Background(), &mutation, vars)
+	err := client.graphql.Mutate(ctx, &mutation, vars)
 	if err != nil {
 		log.Printf("Unable to delete pipeline %s", d.Get("name"))
 		return diag.FromErr(err)
@@ -284,7 +387,7 @@ func DeletePipeline(ctx context.Context, d *schema.ResourceData, m interface{})
 // - branch_configuration
 // - github provider configuration
 // We fallback to REST API
-func updatePipelineWithRESTfulAPI(d *schema.ResourceData, client *Client) error {
+func updatePipelineWithRESTfulAPI(ctx context.Context, d *schema.ResourceData, client *Client) error {
 	slug := d.Get("slug").(string)
 	log.Printf("Updating pipeline %s ...", slug)
 
@@ -292,27 +395,162 @@ func updatePipelineWithRESTfulAPI(d *schema.ResourceData, client *Client) error
 		"branch_configuration": d.Get("branch_configuration").(string),
 	}
 
+	if settings, ok := d.GetOk("provider_settings"); ok {
+		settingsList := settings.([]interface{})
+		if len(settingsList) > 0 {
+			payload["provider_settings"] = settingsList[0]
+		}
+	}
+
 	jsonStr, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("https://api.buildkite.com/v2/organizations/%s/pipelines/%s",
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("https://api.buildkite.com/v2/organizations/%s/pipelines/%s",
 		client.organization, slug), bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return err
 	}
 
-	// a successful response returns 200
 	resp, err := client.http.Do(req)
-	if err != nil && resp.StatusCode != 200 {
+	if err != nil {
 		log.Printf("Unable to update pipeline %s", slug)
 		return err
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unable to update pipeline %s: got HTTP %d", slug, resp.StatusCode)
+	}
 
 	return nil
 }
 
+// restPipeline represents the subset of the REST pipeline representation this provider reads back,
+// since GraphQL does not yet expose provider_settings or branch_configuration.
+type restPipeline struct {
+	BranchConfiguration string               `json:"branch_configuration"`
+	Provider            restPipelineProvider `json:"provider"`
+}
+
+type restPipelineProvider struct {
+	Settings restProviderSettings `json:"settings"`
+}
+
+type restProviderSettings struct {
+	TriggerMode                             string `json:"trigger_mode"`
+	BuildPullRequests                       bool   `json:"build_pull_requests"`
+	BuildPullRequestForks                   bool   `json:"build_pull_request_forks"`
+	PullRequestBranchFilterEnabled          bool   `json:"pull_request_branch_filter_enabled"`
+	PullRequestBranchFilterConfiguration    string `json:"pull_request_branch_filter_configuration"`
+	SkipPullRequestBuildsForExistingCommits bool   `json:"skip_pull_request_builds_for_existing_commits"`
+	BuildTags                               bool   `json:"build_tags"`
+	PublishCommitStatus                     bool   `json:"publish_commit_status"`
+	PublishBlockedAsPending                 bool   `json:"publish_blocked_as_pending"`
+	SeparatePullRequestStatuses             bool   `json:"separate_pull_request_statuses"`
+	FilterEnabled                           bool   `json:"filter_enabled"`
+	FilterCondition                         string `json:"filter_condition"`
+}
+
+// readPipelineWithRESTfulAPI reads the branch_configuration and provider_settings that GraphQL doesn't expose
+func readPipelineWithRESTfulAPI(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	slug := d.Get("slug").(string)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.buildkite.com/v2/organizations/%s/pipelines/%s",
+		client.organization, slug), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		log.Printf("Pipeline %s not found via REST API; leaving branch_configuration/provider_settings as last read", slug)
+		return nil
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unable to read pipeline %s: got HTTP %d", slug, resp.StatusCode)
+	}
+
+	var pipeline restPipeline
+	if err := json.NewDecoder(resp.Body).Decode(&pipeline); err != nil {
+		return err
+	}
+
+	d.Set("branch_configuration", pipeline.BranchConfiguration)
+	d.Set("provider_settings", []map[string]interface{}{providerSettingsToResource(pipeline.Provider.Settings)})
+
+	return nil
+}
+
+// providerSettingsToResource maps the REST API's provider.settings object onto the provider_settings schema block
+func providerSettingsToResource(settings restProviderSettings) map[string]interface{} {
+	return map[string]interface{}{
+		"trigger_mode":                                  settings.TriggerMode,
+		"build_pull_requests":                           settings.BuildPullRequests,
+		"build_pull_request_forks":                      settings.BuildPullRequestForks,
+		"pull_request_branch_filter_enabled":            settings.PullRequestBranchFilterEnabled,
+		"pull_request_branch_filter_configuration":      settings.PullRequestBranchFilterConfiguration,
+		"skip_pull_request_builds_for_existing_commits": settings.SkipPullRequestBuildsForExistingCommits,
+		"build_tags":                                    settings.BuildTags,
+		"publish_commit_status":                         settings.PublishCommitStatus,
+		"publish_blocked_as_pending":                    settings.PublishBlockedAsPending,
+		"separate_pull_request_statuses":                settings.SeparatePullRequestStatuses,
+		"filter_enabled":                                settings.FilterEnabled,
+		"filter_condition":                              settings.FilterCondition,
+	}
+}
+
+// archivePipeline archives a Buildkite pipeline, hiding it from the dashboard without deleting its build history
+func archivePipeline(ctx context.Context, id string, client *Client) error {
+	var mutation struct {
+		PipelineArchive struct {
+			Pipeline PipelineNode
+		} `graphql:"pipelineArchive(input: {id: $id})"`
+	}
+	vars := map[string]interface{}{
+		"id": graphql.ID(id),
+	}
+
+	return client.graphql.Mutate(ctx, &mutation, vars)
+}
+
+// unarchivePipeline restores a previously archived Buildkite pipeline
+func unarchivePipeline(ctx context.Context, id string, client *Client) error {
+	var mutation struct {
+		PipelineUnarchive struct {
+			Pipeline PipelineNode
+		} `graphql:"pipelineUnarchive(input: {id: $id})"`
+	}
+	vars := map[string]interface{}{
+		"id": graphql.ID(id),
+	}
+
+	return client.graphql.Mutate(ctx, &mutation, vars)
+}
+
+// reconcileArchivedState ensures the pipeline's archived state on Buildkite matches the "archived" attribute
+func reconcileArchivedState(ctx context.Context, d *schema.ResourceData, pipeline *PipelineNode, client *Client) error {
+	archived := d.Get("archived").(bool)
+	if archived == bool(pipeline.Archived) {
+		return nil
+	}
+
+	if archived {
+		log.Printf("Archiving pipeline %s ...", pipeline.ID)
+		return archivePipeline(ctx, string(pipeline.ID), client)
+	}
+
+	log.Printf("Unarchiving pipeline %s ...", pipeline.ID)
+	return unarchivePipeline(ctx, string(pipeline.ID), client)
+}
+
 func getTeamPipelinesFromSchema(d *schema.ResourceData) []TeamPipelineNode {
 	teamsInput := d.Get("team").(*schema.Set).List()
 	teamPipelineNodes := make([]TeamPipelineNode, len(teamsInput))
@@ -330,8 +568,97 @@ func getTeamPipelinesFromSchema(d *schema.ResourceData) []TeamPipelineNode {
 	return teamPipelineNodes
 }
 
+// fetchAllTeamPipelines pages through every team granted access to a pipeline, beyond the first 50
+func fetchAllTeamPipelines(ctx context.Context, pipelineID graphql.String, client *Client) ([]TeamPipelineNode, error) {
+	var result []TeamPipelineNode
+
+	err := bkclient.Paginate(func(cursor string) (bkclient.PageInfo, error) {
+		var query struct {
+			Node struct {
+				Pipeline struct {
+					Teams struct {
+						Edges []struct {
+							Node TeamPipelineNode
+						}
+						PageInfo struct {
+							HasNextPage graphql.Boolean
+							EndCursor   graphql.String
+						}
+					} `graphql:"teams(first: 50, after: $after)"`
+				} `graphql:"... on Pipeline"`
+			} `graphql:"node(id: $id)"`
+		}
+		vars := map[string]interface{}{
+			"id":    graphql.ID(pipelineID),
+			"after": graphql.String(cursor),
+		}
+
+		if err := client.graphql.Query(ctx, &query, vars); err != nil {
+			return bkclient.PageInfo{}, err
+		}
+
+		for _, edge := range query.Node.Pipeline.Teams.Edges {
+			result = append(result, edge.Node)
+		}
+
+		return bkclient.PageInfo{
+			HasNextPage: bool(query.Node.Pipeline.Teams.PageInfo.HasNextPage),
+			EndCursor:   string(query.Node.Pipeline.Teams.PageInfo.EndCursor),
+		}, nil
+	})
+
+	return result, err
+}
+
+// fetchAllPipelineSchedules pages through every schedule on a pipeline, beyond the first 50
+func fetchAllPipelineSchedules(ctx context.Context, pipelineID graphql.String, client *Client) ([]PipelineScheduleNode, error) {
+	var result []PipelineScheduleNode
+
+	err := bkclient.Paginate(func(cursor string) (bkclient.PageInfo, error) {
+		var query struct {
+			Node struct {
+				Pipeline struct {
+					Schedules struct {
+						Edges []struct {
+							Node PipelineScheduleNode
+						}
+						PageInfo struct {
+							HasNextPage graphql.Boolean
+							EndCursor   graphql.String
+						}
+					} `graphql:"schedules(first: 50, after: $after)"`
+				} `graphql:"... on Pipeline"`
+			} `graphql:"node(id: $id)"`
+		}
+		vars := map[string]interface{}{
+			"id":    graphql.ID(pipelineID),
+			"after": graphql.String(cursor),
+		}
+
+		if err := client.graphql.Query(ctx, &query, vars); err != nil {
+			return bkclient.PageInfo{}, err
+		}
+
+		for _, edge := range query.Node.Pipeline.Schedules.Edges {
+			result = append(result, edge.Node)
+		}
+
+		return bkclient.PageInfo{
+			HasNextPage: bool(query.Node.Pipeline.Schedules.PageInfo.HasNextPage),
+			EndCursor:   string(query.Node.Pipeline.Schedules.PageInfo.EndCursor),
+		}, nil
+	})
+
+	return result, err
+}
+
 // reconcileTeamPipelines adds/updates/deletes the teamPipelines on buildkite to match the teams in terraform resource data
-func reconcileTeamPipelines(teamPipelines []TeamPipelineNode, pipeline *PipelineNode, client *Client) error {
+func reconcileTeamPipelines(ctx context.Context, teamPipelines []TeamPipelineNode, pipeline *PipelineNode, client *Client) error {
+	existingTeamPipelines, err := fetchAllTeamPipelines(ctx, pipeline.ID, client)
+	if err != nil {
+		return err
+	}
+
 	teamPipelineIds := make(map[string]graphql.ID)
 
 	var toAdd []TeamPipelineNode
@@ -339,10 +666,10 @@ func reconcileTeamPipelines(teamPipelines []TeamPipelineNode, pipeline *Pipeline
 	var toDelete []TeamPipelineNode
 
 	// Look for teamPipelines on buildkite that need updated or removed
-	for _, teamPipeline := range pipeline.Teams.Edges {
-		teamSlugBk := teamPipeline.Node.Team.Slug
-		accessLevelBk := teamPipeline.Node.AccessLevel
-		id := teamPipeline.Node.ID
+	for _, teamPipeline := range existingTeamPipelines {
+		teamSlugBk := teamPipeline.Team.Slug
+		accessLevelBk := teamPipeline.AccessLevel
+		id := teamPipeline.ID
 
 		teamPipelineIds[string(teamSlugBk)] = graphql.ID(id)
 
@@ -382,19 +709,19 @@ func reconcileTeamPipelines(teamPipelines []TeamPipelineNode, pipeline *Pipeline
 	log.Printf("EXISTING_BUILDKITE_TEAMS: %s", teamPipelineIds)
 
 	// Add any teamsInput that don't already exist
-	err := createTeamPipelines(toAdd, string(pipeline.ID), client)
+	err = createTeamPipelines(ctx, toAdd, string(pipeline.ID), client)
 	if err != nil {
 		return err
 	}
 
 	// Update any teamsInput access levels that need updating
-	err = updateTeamPipelines(toUpdate, client)
+	err = updateTeamPipelines(ctx, toUpdate, client)
 	if err != nil {
 		return err
 	}
 
 	// Remove any teamsInput that shouldn't exist
-	err = deleteTeamPipelines(toDelete, client)
+	err = deleteTeamPipelines(ctx, toDelete, client)
 	if err != nil {
 		return err
 	}
@@ -403,7 +730,7 @@ func reconcileTeamPipelines(teamPipelines []TeamPipelineNode, pipeline *Pipeline
 }
 
 // createTeamPipelines grants access to a pipeline for the teams specified
-func createTeamPipelines(teamPipelines []TeamPipelineNode, pipelineID string, client *Client) error {
+func createTeamPipelines(ctx context.Context, teamPipelines []TeamPipelineNode, pipelineID string, client *Client) error {
 	var mutation struct {
 		TeamPipelineCreate struct {
 			TeamPipeline struct {
@@ -423,7 +750,7 @@ func createTeamPipelines(teamPipelines []TeamPipelineNode, pipelineID string, cl
 			"pipeline":    graphql.ID(pipelineID),
 			"accessLevel": teamPipeline.AccessLevel,
 		}
-		err = client.graphql.Mutate(context.Background(), &mutation, params)
+		err = client.graphql.Mutate(ctx, &mutation, params)
 		if err != nil {
 			log.Printf("Unable to create team pipeline %s", teamPipeline.Team.Slug)
 			return err
@@ -433,7 +760,7 @@ func createTeamPipelines(teamPipelines []TeamPipelineNode, pipelineID string, cl
 }
 
 // Update access levels for the given teamPipelines
-func updateTeamPipelines(teamPipelines []TeamPipelineNode, client *Client) error {
+func updateTeamPipelines(ctx context.Context, teamPipelines []TeamPipelineNode, client *Client) error {
 	var mutation struct {
 		TeamPipelineUpdate struct {
 			TeamPipeline struct {
@@ -447,7 +774,7 @@ func updateTeamPipelines(teamPipelines []TeamPipelineNode, client *Client) error
 			"id":          graphql.ID(string(teamPipeline.ID)),
 			"accessLevel": teamPipeline.AccessLevel,
 		}
-		err := client.graphql.Mutate(context.Background(), &mutation, params)
+		err := client.graphql.Mutate(ctx, &mutation, params)
 		if err != nil {
 			log.Printf("Unable to update team pipeline")
 			return err
@@ -456,7 +783,7 @@ func updateTeamPipelines(teamPipelines []TeamPipelineNode, client *Client) error
 	return nil
 }
 
-func deleteTeamPipelines(teamPipelines []TeamPipelineNode, client *Client) error {
+func deleteTeamPipelines(ctx context.Context, teamPipelines []TeamPipelineNode, client *Client) error {
 	var mutation struct {
 		TeamPipelineDelete struct {
 			Team struct {
@@ -469,7 +796,7 @@ func deleteTeamPipelines(teamPipelines []TeamPipelineNode, client *Client) error
 		params := map[string]interface{}{
 			"id": graphql.ID(string(teamPipeline.ID)),
 		}
-		err := client.graphql.Mutate(context.Background(), &mutation, params)
+		err := client.graphql.Mutate(ctx, &mutation, params)
 		if err != nil {
 			log.Printf("Unable to delete team pipeline")
 			return err
@@ -480,8 +807,9 @@ func deleteTeamPipelines(teamPipelines []TeamPipelineNode, client *Client) error
 }
 
 // updatePipelineResource updates the terraform resource data for the pipeline resource
-func updatePipelineResource(d *schema.ResourceData, pipeline *PipelineNode) {
+func updatePipelineResource(ctx context.Context, d *schema.ResourceData, pipeline *PipelineNode, client *Client) error {
 	d.SetId(string(pipeline.ID))
+	d.Set("archived", bool(pipeline.Archived))
 	d.Set("cancel_intermediate_builds", bool(pipeline.CancelIntermediateBuilds))
 	d.Set("cancel_intermediate_builds_branch_filter", string(pipeline.CancelIntermediateBuildsBranchFilter))
 	d.Set("default_branch", string(pipeline.DefaultBranch))
@@ -494,13 +822,19 @@ func updatePipelineResource(d *schema.ResourceData, pipeline *PipelineNode) {
 	d.Set("steps", string(pipeline.Steps.YAML))
 	d.Set("webhook_url", string(pipeline.WebhookURL))
 
-	teams := make([]map[string]interface{}, len(pipeline.Teams.Edges))
-	for i, id := range pipeline.Teams.Edges {
-		team := map[string]interface{}{
-			"slug":         string(id.Node.Team.Slug),
-			"access_level": string(id.Node.AccessLevel),
+	teamPipelines, err := fetchAllTeamPipelines(ctx, pipeline.ID, client)
+	if err != nil {
+		return err
+	}
+
+	teams := make([]map[string]interface{}, len(teamPipelines))
+	for i, teamPipeline := range teamPipelines {
+		teams[i] = map[string]interface{}{
+			"slug":         string(teamPipeline.Team.Slug),
+			"access_level": string(teamPipeline.AccessLevel),
 		}
-		teams[i] = team
 	}
 	d.Set("team", teams)
+
+	return nil
 }
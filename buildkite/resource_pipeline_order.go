@@ -0,0 +1,110 @@
+package buildkite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourcePipelineOrder represents the terraform pipeline_order resource schema
+func resourcePipelineOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreatePipelineOrder,
+		ReadContext:   ReadPipelineOrder,
+		UpdateContext: UpdatePipelineOrder,
+		DeleteContext: DeletePipelineOrder,
+
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"pipeline_slugs": {
+				Required: true,
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// CreatePipelineOrder applies the desired pipeline display order for the first time
+func CreatePipelineOrder(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	if err := orderPipelines(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(client.organization)
+	if group := d.Get("group").(string); group != "" {
+		d.SetId(fmt.Sprintf("%s/%s", client.organization, group))
+	}
+
+	return nil
+}
+
+// ReadPipelineOrder is a no-op: pipeline order isn't independently queryable from the slugs we manage
+func ReadPipelineOrder(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+// UpdatePipelineOrder re-applies the desired pipeline display order
+func UpdatePipelineOrder(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	if err := orderPipelines(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// DeletePipelineOrder leaves pipelines in their current order: there's nothing meaningful to reset to
+func DeletePipelineOrder(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+// orderPipelines sets each pipeline's position (and optional group) via the REST API, in the order given
+func orderPipelines(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	group := d.Get("group").(string)
+	slugs := d.Get("pipeline_slugs").([]interface{})
+
+	for position, s := range slugs {
+		slug := s.(string)
+		payload := map[string]interface{}{
+			"position": position,
+		}
+		if group != "" {
+			payload["group"] = group
+		}
+
+		jsonStr, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Setting pipeline %s to position %d in group %q ...", slug, position, group)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("https://api.buildkite.com/v2/organizations/%s/pipelines/%s",
+			client.organization, slug), bytes.NewBuffer(jsonStr))
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.http.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("unable to set position for pipeline %s: got HTTP %d", slug, resp.StatusCode)
+		}
+	}
+
+	return nil
+}
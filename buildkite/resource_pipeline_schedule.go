@@ -0,0 +1,236 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/graphql"
+)
+
+// PipelineScheduleNode represents a pipeline schedule as returned from the GraphQL API
+type PipelineScheduleNode struct {
+	Branch   graphql.String
+	Commit   graphql.String
+	Cronline graphql.String
+	Enabled  graphql.Boolean
+	Env      graphql.String
+	ID       graphql.String
+	Label    graphql.String
+	Message  graphql.String
+}
+
+// resourcePipelineSchedule represents the terraform pipeline_schedule resource schema
+func resourcePipelineSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreatePipelineSchedule,
+		ReadContext:   ReadPipelineSchedule,
+		UpdateContext: UpdatePipelineSchedule,
+		DeleteContext: DeletePipelineSchedule,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Required: true,
+				ForceNew: true,
+				Type:     schema.TypeString,
+			},
+			"label": {
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"cronline": {
+				Required: true,
+				Type:     schema.TypeString,
+			},
+			"branch": {
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"commit": {
+				Optional: true,
+				Default:  "HEAD",
+				Type:     schema.TypeString,
+			},
+			"message": {
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+			"env": {
+				Optional: true,
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"enabled": {
+				Optional: true,
+				Default:  true,
+				Type:     schema.TypeBool,
+			},
+		},
+	}
+}
+
+// CreatePipelineSchedule creates a Buildkite pipeline schedule
+func CreatePipelineSchedule(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var mutation struct {
+		PipelineScheduleCreate struct {
+			PipelineSchedule PipelineScheduleNode
+		} `graphql:"pipelineScheduleCreate(input: {pipelineID: $pipeline_id, label: $label, cronline: $cronline, branch: $branch, commit: $commit, message: $message, env: $env, enabled: $enabled})"`
+	}
+	vars := map[string]interface{}{
+		"pipeline_id": graphql.ID(d.Get("pipeline_id").(string)),
+		"label":       graphql.String(d.Get("label").(string)),
+		"cronline":    graphql.String(d.Get("cronline").(string)),
+		"branch":      graphql.String(d.Get("branch").(string)),
+		"commit":      graphql.String(d.Get("commit").(string)),
+		"message":     graphql.String(d.Get("message").(string)),
+		"env":         graphql.String(envMapToString(d.Get("env").(map[string]interface{}))),
+		"enabled":     graphql.Boolean(d.Get("enabled").(bool)),
+	}
+
+	log.Printf("Creating pipeline schedule %s ...", vars["label"])
+	err := client.graphql.Mutate(ctx, &mutation, vars)
+	if err != nil {
+		log.Printf("Unable to create pipeline schedule %s", d.Get("label"))
+		return diag.FromErr(err)
+	}
+	log.Printf("Successfully created pipeline schedule with id '%s'.", mutation.PipelineScheduleCreate.PipelineSchedule.ID)
+
+	updatePipelineScheduleResource(d, &mutation.PipelineScheduleCreate.PipelineSchedule)
+
+	return ReadPipelineSchedule(ctx, d, m)
+}
+
+// ReadPipelineSchedule retrieves a Buildkite pipeline schedule
+func ReadPipelineSchedule(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	var query struct {
+		Node struct {
+			PipelineSchedule PipelineScheduleNode `graphql:"... on PipelineSchedule"`
+		} `graphql:"node(id: $id)"`
+	}
+	vars := map[string]interface{}{
+		"id": graphql.ID(d.Id()),
+	}
+
+	err := client.graphql.Query(ctx, &query, vars)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	updatePipelineScheduleResource(d, &query.Node.PipelineSchedule)
+
+	return nil
+}
+
+// UpdatePipelineSchedule updates a Buildkite pipeline schedule
+func UpdatePipelineSchedule(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	var mutation struct {
+		PipelineScheduleUpdate struct {
+			PipelineSchedule PipelineScheduleNode
+		} `graphql:"pipelineScheduleUpdate(input: {id: $id, label: $label, cronline: $cronline, branch: $branch, commit: $commit, message: $message, env: $env, enabled: $enabled})"`
+	}
+	vars := map[string]interface{}{
+		"id":       graphql.ID(d.Id()),
+		"label":    graphql.String(d.Get("label").(string)),
+		"cronline": graphql.String(d.Get("cronline").(string)),
+		"branch":   graphql.String(d.Get("branch").(string)),
+		"commit":   graphql.String(d.Get("commit").(string)),
+		"message":  graphql.String(d.Get("message").(string)),
+		"env":      graphql.String(envMapToString(d.Get("env").(map[string]interface{}))),
+		"enabled":  graphql.Boolean(d.Get("enabled").(bool)),
+	}
+
+	log.Printf("Updating pipeline schedule %s ...", vars["label"])
+	err := client.graphql.Mutate(ctx, &mutation, vars)
+	if err != nil {
+		log.Printf("Unable to update pipeline schedule %s", d.Get("label"))
+		return diag.FromErr(err)
+	}
+
+	updatePipelineScheduleResource(d, &mutation.PipelineScheduleUpdate.PipelineSchedule)
+
+	return ReadPipelineSchedule(ctx, d, m)
+}
+
+// DeletePipelineSchedule removes a Buildkite pipeline schedule
+func DeletePipelineSchedule(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var mutation struct {
+		PipelineScheduleDelete struct {
+			Pipeline struct {
+				ID graphql.ID
+			}
+		} `graphql:"pipelineScheduleDelete(input: {id: $id})"`
+	}
+	vars := map[string]interface{}{
+		"id": graphql.ID(d.Id()),
+	}
+
+	log.Printf("Deleting pipeline schedule %s ...", d.Get("label"))
+	err := client.graphql.Mutate(ctx, &mutation, vars)
+	if err != nil {
+		log.Printf("Unable to delete pipeline schedule %s", d.Get("label"))
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// updatePipelineScheduleResource updates the terraform resource data for the pipeline_schedule resource
+func updatePipelineScheduleResource(d *schema.ResourceData, schedule *PipelineScheduleNode) {
+	d.SetId(string(schedule.ID))
+	d.Set("label", string(schedule.Label))
+	d.Set("cronline", string(schedule.Cronline))
+	d.Set("branch", string(schedule.Branch))
+	d.Set("commit", string(schedule.Commit))
+	d.Set("message", string(schedule.Message))
+	d.Set("env", stringToEnvMap(string(schedule.Env)))
+	d.Set("enabled", bool(schedule.Enabled))
+}
+
+// envMapToString flattens a schedule's env map into the newline-delimited KEY=value form the Buildkite API expects
+func envMapToString(env map[string]interface{}) string {
+	if len(env) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(env))
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, env[k]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stringToEnvMap parses the newline-delimited KEY=value form the Buildkite API returns back into a map
+func stringToEnvMap(env string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range strings.Split(env, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result
+}
@@ -0,0 +1,398 @@
+package buildkite
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/graphql"
+	bkclient "github.com/vgrigoruk/terraform-provider-buildkite/buildkite/client"
+	"gopkg.in/yaml.v2"
+)
+
+// pipelinesManifest is the top-level shape of the YAML document accepted by buildkite_pipelines_from_yaml
+type pipelinesManifest struct {
+	Pipelines []manifestPipeline `yaml:"pipelines"`
+}
+
+type manifestPipeline struct {
+	Name        string `yaml:"name"`
+	Slug        string `yaml:"slug"`
+	Description string `yaml:"description"`
+	Public      bool   `yaml:"public"`
+	Steps       string `yaml:"steps"`
+	Repository  string `yaml:"repository"`
+	// Provider is accepted for pipegen config compatibility but isn't wired into create/update:
+	// Buildkite derives the repository provider from the repository URL and doesn't expose a way
+	// to set it independently, so this field is currently a documented no-op.
+	Provider      string                `yaml:"provider"`
+	Teams         []manifestTeam        `yaml:"teams"`
+	BuildSkipping manifestBuildSkipping `yaml:"build_skipping"`
+	Schedules     []manifestSchedule    `yaml:"schedules"`
+}
+
+type manifestTeam struct {
+	Slug        string `yaml:"slug"`
+	AccessLevel string `yaml:"access_level"`
+}
+
+type manifestBuildSkipping struct {
+	SkipIntermediateBuilds               bool   `yaml:"skip"`
+	SkipIntermediateBuildsBranchFilter   string `yaml:"skip_branch_filter"`
+	CancelIntermediateBuilds             bool   `yaml:"cancel"`
+	CancelIntermediateBuildsBranchFilter string `yaml:"cancel_branch_filter"`
+}
+
+type manifestSchedule struct {
+	Label    string            `yaml:"label"`
+	Cronline string            `yaml:"cronline"`
+	Branch   string            `yaml:"branch"`
+	Commit   string            `yaml:"commit"`
+	Message  string            `yaml:"message"`
+	Env      map[string]string `yaml:"env"`
+	Enabled  bool              `yaml:"enabled"`
+}
+
+// resourcePipelinesFromYAML represents the terraform pipelines_from_yaml resource schema
+func resourcePipelinesFromYAML() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreatePipelinesFromYAML,
+		ReadContext:   ReadPipelinesFromYAML,
+		UpdateContext: UpdatePipelinesFromYAML,
+		DeleteContext: DeletePipelinesFromYAML,
+
+		Schema: map[string]*schema.Schema{
+			"yaml": {
+				Required: true,
+				Type:     schema.TypeString,
+			},
+			"managed_slugs": {
+				Computed: true,
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// CreatePipelinesFromYAML reconciles the organization's pipelines against the manifest for the first time
+func CreatePipelinesFromYAML(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return reconcilePipelinesFromYAML(ctx, d, m)
+}
+
+// UpdatePipelinesFromYAML reconciles the organization's pipelines against a changed manifest
+func UpdatePipelinesFromYAML(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return reconcilePipelinesFromYAML(ctx, d, m)
+}
+
+// ReadPipelinesFromYAML refreshes the list of slugs this resource currently manages
+func ReadPipelinesFromYAML(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var manifest pipelinesManifest
+	if err := yaml.Unmarshal([]byte(d.Get("yaml").(string)), &manifest); err != nil {
+		return diag.FromErr(err)
+	}
+
+	existing, err := listOrganizationPipelines(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	slugs := make([]string, 0, len(manifest.Pipelines))
+	for _, p := range manifest.Pipelines {
+		if _, found := existing[p.Slug]; found {
+			slugs = append(slugs, p.Slug)
+		}
+	}
+	d.Set("managed_slugs", slugs)
+
+	return nil
+}
+
+// DeletePipelinesFromYAML leaves managed pipelines in place: removing a pipeline from the manifest already
+// reconciles it away on the next apply, so destroying this resource should not be a surprise mass-delete.
+func DeletePipelinesFromYAML(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// reconcilePipelinesFromYAML lists every pipeline in the organization, diffs it against the manifest by slug,
+// and creates/updates/deletes pipelines (plus their teams and schedules) to converge on the desired state.
+func reconcilePipelinesFromYAML(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var manifest pipelinesManifest
+	if err := yaml.Unmarshal([]byte(d.Get("yaml").(string)), &manifest); err != nil {
+		return diag.FromErr(err)
+	}
+
+	existing, err := listOrganizationPipelines(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	orgID, err := GetOrganizationID(client.organization, client.graphql)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	desired := make(map[string]manifestPipeline, len(manifest.Pipelines))
+	managedSlugs := make([]string, 0, len(manifest.Pipelines))
+	for _, p := range manifest.Pipelines {
+		desired[p.Slug] = p
+		managedSlugs = append(managedSlugs, p.Slug)
+
+		pipeline, found := existing[p.Slug]
+		if !found {
+			log.Printf("Creating pipeline %s from manifest ...", p.Slug)
+			pipeline, err = createPipelineFromManifest(ctx, p, orgID, client)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("unable to create pipeline %s: %w", p.Slug, err))
+			}
+		} else {
+			log.Printf("Updating pipeline %s from manifest ...", p.Slug)
+			pipeline, err = updatePipelineFromManifest(ctx, p, pipeline, client)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("unable to update pipeline %s: %w", p.Slug, err))
+			}
+		}
+
+		teamPipelines := make([]TeamPipelineNode, len(p.Teams))
+		for i, t := range p.Teams {
+			teamPipelines[i] = TeamPipelineNode{
+				AccessLevel: PipelineAccessLevels(t.AccessLevel),
+				Team:        TeamNode{Slug: graphql.String(t.Slug)},
+			}
+		}
+		if err := reconcileTeamPipelines(ctx, teamPipelines, &pipeline, client); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to reconcile teams for pipeline %s: %w", p.Slug, err))
+		}
+
+		if err := reconcileManifestSchedules(ctx, p.Schedules, &pipeline, client); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to reconcile schedules for pipeline %s: %w", p.Slug, err))
+		}
+	}
+
+	for slug, pipeline := range existing {
+		if _, found := desired[slug]; !found {
+			log.Printf("Deleting pipeline %s: no longer present in manifest ...", slug)
+			if err := deletePipelineByID(ctx, string(pipeline.ID), client); err != nil {
+				return diag.FromErr(fmt.Errorf("unable to delete pipeline %s: %w", slug, err))
+			}
+		}
+	}
+
+	d.SetId(client.organization)
+	d.Set("managed_slugs", managedSlugs)
+
+	return nil
+}
+
+// listOrganizationPipelines pages through every pipeline in the organization, keyed by slug
+func listOrganizationPipelines(ctx context.Context, client *Client) (map[string]PipelineNode, error) {
+	result := make(map[string]PipelineNode)
+
+	err := bkclient.Paginate(func(cursor string) (bkclient.PageInfo, error) {
+		var query struct {
+			Organization struct {
+				Pipelines struct {
+					Edges []struct {
+						Node PipelineNode
+					}
+					PageInfo struct {
+						HasNextPage graphql.Boolean
+						EndCursor   graphql.String
+					}
+				} `graphql:"pipelines(first: 50, after: $after)"`
+			} `graphql:"organization(slug: $org)"`
+		}
+		vars := map[string]interface{}{
+			"org":   graphql.String(client.organization),
+			"after": graphql.String(cursor),
+		}
+
+		if err := client.graphql.Query(ctx, &query, vars); err != nil {
+			return bkclient.PageInfo{}, err
+		}
+
+		for _, edge := range query.Organization.Pipelines.Edges {
+			result[string(edge.Node.Slug)] = edge.Node
+		}
+
+		return bkclient.PageInfo{
+			HasNextPage: bool(query.Organization.Pipelines.PageInfo.HasNextPage),
+			EndCursor:   string(query.Organization.Pipelines.PageInfo.EndCursor),
+		}, nil
+	})
+
+	return result, err
+}
+
+// pipelineVisibility maps the manifest's "public" flag onto the pipelineCreate/pipelineUpdate visibility enum
+func pipelineVisibility(public bool) graphql.String {
+	if public {
+		return "PUBLIC"
+	}
+	return "PRIVATE"
+}
+
+// createPipelineFromManifest creates a single pipeline described in the manifest
+func createPipelineFromManifest(ctx context.Context, p manifestPipeline, orgID graphql.ID, client *Client) (PipelineNode, error) {
+	var mutation struct {
+		PipelineCreate struct {
+			Pipeline PipelineNode
+		} `graphql:"pipelineCreate(input: {cancelIntermediateBuilds: $cancel, cancelIntermediateBuildsBranchFilter: $cancel_filter, description: $desc, name: $name, organizationId: $org, repository: {url: $repository_url}, skipIntermediateBuilds: $skip, skipIntermediateBuildsBranchFilter: $skip_filter, steps: {yaml: $steps}, visibility: $visibility})"`
+	}
+	vars := map[string]interface{}{
+		"cancel":         graphql.Boolean(p.BuildSkipping.CancelIntermediateBuilds),
+		"cancel_filter":  graphql.String(p.BuildSkipping.CancelIntermediateBuildsBranchFilter),
+		"desc":           graphql.String(p.Description),
+		"name":           graphql.String(p.Name),
+		"org":            orgID,
+		"repository_url": graphql.String(p.Repository),
+		"skip":           graphql.Boolean(p.BuildSkipping.SkipIntermediateBuilds),
+		"skip_filter":    graphql.String(p.BuildSkipping.SkipIntermediateBuildsBranchFilter),
+		"steps":          graphql.String(p.Steps),
+		"visibility":     pipelineVisibility(p.Public),
+	}
+
+	err := client.graphql.Mutate(ctx, &mutation, vars)
+	return mutation.PipelineCreate.Pipeline, err
+}
+
+// updatePipelineFromManifest updates a single pipeline to match the manifest
+func updatePipelineFromManifest(ctx context.Context, p manifestPipeline, existing PipelineNode, client *Client) (PipelineNode, error) {
+	var mutation struct {
+		PipelineUpdate struct {
+			Pipeline PipelineNode
+		} `graphql:"pipelineUpdate(input: {cancelIntermediateBuilds: $cancel, cancelIntermediateBuildsBranchFilter: $cancel_filter, description: $desc, id: $id, name: $name, repository: {url: $repository_url}, skipIntermediateBuilds: $skip, skipIntermediateBuildsBranchFilter: $skip_filter, steps: {yaml: $steps}, visibility: $visibility})"`
+	}
+	vars := map[string]interface{}{
+		"cancel":         graphql.Boolean(p.BuildSkipping.CancelIntermediateBuilds),
+		"cancel_filter":  graphql.String(p.BuildSkipping.CancelIntermediateBuildsBranchFilter),
+		"desc":           graphql.String(p.Description),
+		"id":             existing.ID,
+		"name":           graphql.String(p.Name),
+		"repository_url": graphql.String(p.Repository),
+		"skip":           graphql.Boolean(p.BuildSkipping.SkipIntermediateBuilds),
+		"skip_filter":    graphql.String(p.BuildSkipping.SkipIntermediateBuildsBranchFilter),
+		"steps":          graphql.String(p.Steps),
+		"visibility":     pipelineVisibility(p.Public),
+	}
+
+	err := client.graphql.Mutate(ctx, &mutation, vars)
+	return mutation.PipelineUpdate.Pipeline, err
+}
+
+// deletePipelineByID removes a pipeline that is no longer present in the manifest
+func deletePipelineByID(ctx context.Context, id string, client *Client) error {
+	var mutation struct {
+		PipelineDelete struct {
+			Organization struct {
+				ID graphql.ID
+			}
+		} `graphql:"pipelineDelete(input: {id: $id})"`
+	}
+	vars := map[string]interface{}{
+		"id": graphql.ID(id),
+	}
+
+	return client.graphql.Mutate(ctx, &mutation, vars)
+}
+
+// reconcileManifestSchedules adds/updates/deletes a pipeline's schedules to match the manifest, keyed by label
+func reconcileManifestSchedules(ctx context.Context, schedules []manifestSchedule, pipeline *PipelineNode, client *Client) error {
+	existingSchedules, err := fetchAllPipelineSchedules(ctx, pipeline.ID, client)
+	if err != nil {
+		return err
+	}
+
+	existingByLabel := make(map[string]PipelineScheduleNode, len(existingSchedules))
+	for _, schedule := range existingSchedules {
+		existingByLabel[string(schedule.Label)] = schedule
+	}
+
+	desiredLabels := make(map[string]bool, len(schedules))
+	for _, s := range schedules {
+		desiredLabels[s.Label] = true
+
+		if existing, found := existingByLabel[s.Label]; found {
+			if err := updateManifestSchedule(ctx, s, existing, client); err != nil {
+				return err
+			}
+		} else {
+			if err := createManifestSchedule(ctx, s, string(pipeline.ID), client); err != nil {
+				return err
+			}
+		}
+	}
+
+	for label, existing := range existingByLabel {
+		if !desiredLabels[label] {
+			if err := deleteManifestSchedule(ctx, string(existing.ID), client); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func createManifestSchedule(ctx context.Context, s manifestSchedule, pipelineID string, client *Client) error {
+	var mutation struct {
+		PipelineScheduleCreate struct {
+			PipelineSchedule PipelineScheduleNode
+		} `graphql:"pipelineScheduleCreate(input: {pipelineID: $pipeline_id, label: $label, cronline: $cronline, branch: $branch, commit: $commit, message: $message, env: $env, enabled: $enabled})"`
+	}
+	vars := manifestScheduleVars(s)
+	vars["pipeline_id"] = graphql.ID(pipelineID)
+
+	return client.graphql.Mutate(ctx, &mutation, vars)
+}
+
+func updateManifestSchedule(ctx context.Context, s manifestSchedule, existing PipelineScheduleNode, client *Client) error {
+	var mutation struct {
+		PipelineScheduleUpdate struct {
+			PipelineSchedule PipelineScheduleNode
+		} `graphql:"pipelineScheduleUpdate(input: {id: $id, label: $label, cronline: $cronline, branch: $branch, commit: $commit, message: $message, env: $env, enabled: $enabled})"`
+	}
+	vars := manifestScheduleVars(s)
+	vars["id"] = existing.ID
+
+	return client.graphql.Mutate(ctx, &mutation, vars)
+}
+
+func deleteManifestSchedule(ctx context.Context, id string, client *Client) error {
+	var mutation struct {
+		PipelineScheduleDelete struct {
+			Pipeline struct {
+				ID graphql.ID
+			}
+		} `graphql:"pipelineScheduleDelete(input: {id: $id})"`
+	}
+	vars := map[string]interface{}{
+		"id": graphql.ID(id),
+	}
+
+	return client.graphql.Mutate(ctx, &mutation, vars)
+}
+
+func manifestScheduleVars(s manifestSchedule) map[string]interface{} {
+	env := make(map[string]interface{}, len(s.Env))
+	for k, v := range s.Env {
+		env[k] = v
+	}
+
+	return map[string]interface{}{
+		"label":    graphql.String(s.Label),
+		"cronline": graphql.String(s.Cronline),
+		"branch":   graphql.String(s.Branch),
+		"commit":   graphql.String(s.Commit),
+		"message":  graphql.String(s.Message),
+		"env":      graphql.String(envMapToString(env)),
+		"enabled":  graphql.Boolean(s.Enabled),
+	}
+}